@@ -0,0 +1,122 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package camera provides a reusable 2D camera and viewport for ebiten games,
+// including world<->screen conversions and isometric helpers, so that games
+// no longer need to hand-roll this math for every project.
+package camera
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Camera represents a 2D view into the game world: a position, a zoom level
+// and a rotation, expressed in world coordinates.
+type Camera struct {
+	X, Y     float64
+	Zoom     float64
+	Rotation float64
+}
+
+// NewCamera returns a new Camera centered at (x, y) with a zoom of 1 and no
+// rotation.
+func NewCamera(x, y float64) *Camera {
+	return &Camera{X: x, Y: y, Zoom: 1}
+}
+
+// Viewport combines a Camera with the size of the screen it is rendered to,
+// and exposes the coordinate conversions games need to place world objects
+// on screen and to interpret screen input (such as mouse clicks) in world
+// space.
+type Viewport struct {
+	Camera *Camera
+	Width  int
+	Height int
+}
+
+// NewViewport returns a new Viewport of the given size, driven by camera.
+func NewViewport(camera *Camera, width, height int) *Viewport {
+	return &Viewport{Camera: camera, Width: width, Height: height}
+}
+
+// WorldToScreen converts a point in world coordinates to screen coordinates
+// under the current camera transform.
+func (v *Viewport) WorldToScreen(x, y float64) (float64, float64) {
+	x -= v.Camera.X
+	y -= v.Camera.Y
+
+	if v.Camera.Rotation != 0 {
+		sin, cos := math.Sincos(v.Camera.Rotation)
+		x, y = x*cos-y*sin, x*sin+y*cos
+	}
+
+	x *= v.Camera.Zoom
+	y *= v.Camera.Zoom
+
+	return x + float64(v.Width)/2, y + float64(v.Height)/2
+}
+
+// ScreenToWorld converts a point in screen coordinates, such as the result
+// of ebiten.CursorPosition, to world coordinates under the current camera
+// transform. It is the inverse of WorldToScreen.
+func (v *Viewport) ScreenToWorld(x, y float64) (float64, float64) {
+	x -= float64(v.Width) / 2
+	y -= float64(v.Height) / 2
+
+	x /= v.Camera.Zoom
+	y /= v.Camera.Zoom
+
+	if v.Camera.Rotation != 0 {
+		sin, cos := math.Sincos(-v.Camera.Rotation)
+		x, y = x*cos-y*sin, x*sin+y*cos
+	}
+
+	return x + v.Camera.X, y + v.Camera.Y
+}
+
+// Apply applies the Viewport's world-to-screen transform to op, so that an
+// image drawn with op is positioned, zoomed and rotated as seen through the
+// camera. The image's own position, set before calling Apply, is treated as
+// a world-space translation.
+func (v *Viewport) Apply(op *ebiten.DrawImageOptions) {
+	op.GeoM.Translate(-v.Camera.X, -v.Camera.Y)
+	if v.Camera.Rotation != 0 {
+		op.GeoM.Rotate(v.Camera.Rotation)
+	}
+	op.GeoM.Scale(v.Camera.Zoom, v.Camera.Zoom)
+	op.GeoM.Translate(float64(v.Width)/2, float64(v.Height)/2)
+}
+
+// IsRectVisible reports whether the given rectangle, in world coordinates,
+// intersects the Viewport's visible area. Tile and sprite renderers can use
+// this to skip off-screen draws without hand-rolled math.
+func (v *Viewport) IsRectVisible(r image.Rectangle) bool {
+	// With Camera.Rotation set, the screen-space bounding box of a rotated
+	// rect can extend past the transformed Min/Max corners, so all 4
+	// corners must be transformed rather than just those two.
+	x0, y0 := v.WorldToScreen(float64(r.Min.X), float64(r.Min.Y))
+	x1, y1 := v.WorldToScreen(float64(r.Max.X), float64(r.Min.Y))
+	x2, y2 := v.WorldToScreen(float64(r.Max.X), float64(r.Max.Y))
+	x3, y3 := v.WorldToScreen(float64(r.Min.X), float64(r.Max.Y))
+
+	minX := math.Min(math.Min(x0, x1), math.Min(x2, x3))
+	maxX := math.Max(math.Max(x0, x1), math.Max(x2, x3))
+	minY := math.Min(math.Min(y0, y1), math.Min(y2, y3))
+	maxY := math.Max(math.Max(y0, y1), math.Max(y2, y3))
+
+	return maxX >= 0 && minX <= float64(v.Width) && maxY >= 0 && minY <= float64(v.Height)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestViewportWorldScreenRoundTrip(t *testing.T) {
+	v := NewViewport(NewCamera(100, -50), 800, 600)
+	v.Camera.Zoom = 2
+	v.Camera.Rotation = 0.4
+
+	cases := []struct{ x, y float64 }{
+		{0, 0},
+		{100, -50},
+		{250.5, 10},
+	}
+	for _, c := range cases {
+		sx, sy := v.WorldToScreen(c.x, c.y)
+		gotX, gotY := v.ScreenToWorld(sx, sy)
+		if math.Abs(gotX-c.x) > 1e-9 || math.Abs(gotY-c.y) > 1e-9 {
+			t.Errorf("round trip for (%v, %v): got (%v, %v)", c.x, c.y, gotX, gotY)
+		}
+	}
+}
+
+func TestViewportIsRectVisible(t *testing.T) {
+	v := NewViewport(NewCamera(0, 0), 800, 600)
+
+	if !v.IsRectVisible(image.Rect(-10, -10, 10, 10)) {
+		t.Error("rect at camera center should be visible")
+	}
+	if v.IsRectVisible(image.Rect(100000, 100000, 100010, 100010)) {
+		t.Error("rect far outside the viewport should not be visible")
+	}
+}
+
+// TestViewportIsRectVisibleRotated checks a rect that overlaps the screen
+// under camera rotation even though the bounding box of its Min and Max
+// corners alone, transformed to screen space, lies wholly off screen.
+func TestViewportIsRectVisibleRotated(t *testing.T) {
+	v := NewViewport(NewCamera(0, 0), 100, 100)
+	v.Camera.Rotation = math.Pi / 4
+
+	if !v.IsRectVisible(image.Rect(-170, -5, -70, 5)) {
+		t.Error("rect should be visible once all 4 rotated corners are considered")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera
+
+import "math"
+
+// Follow moves the Camera a fraction smoothing of the way towards (x, y)
+// every call, producing a smooth-follow effect when called once per Update
+// with a target's position. smoothing is typically a small value such as
+// 0.1; 1 snaps immediately to the target.
+func (c *Camera) Follow(x, y, smoothing float64) {
+	c.X += (x - c.X) * smoothing
+	c.Y += (y - c.Y) * smoothing
+}
+
+// ClampToBounds clamps the Camera's position so that the visible viewport
+// shows nothing outside of the given world bounds, along each axis where the
+// bounds are at least as large as the visible area. Along an axis where they
+// are smaller, the Camera is centered on the bounds instead.
+func (v *Viewport) ClampToBounds(minX, minY, maxX, maxY float64) {
+	halfW := float64(v.Width) / 2 / v.Camera.Zoom
+	halfH := float64(v.Height) / 2 / v.Camera.Zoom
+
+	if lo, hi := minX+halfW, maxX-halfW; lo <= hi {
+		v.Camera.X = math.Min(math.Max(v.Camera.X, lo), hi)
+	} else {
+		v.Camera.X = (minX + maxX) / 2
+	}
+	if lo, hi := minY+halfH, maxY-halfH; lo <= hi {
+		v.Camera.Y = math.Min(math.Max(v.Camera.Y, lo), hi)
+	} else {
+		v.Camera.Y = (minY + maxY) / 2
+	}
+}
+
+// DragPan is a helper for mouse-drag panning. Call Update every tick with the
+// current pressed state and cursor position; while pressed is true, the
+// Camera is panned by the movement of the cursor since the previous call.
+//
+// A DragPan's zero value is ready to use. It must persist between calls, for
+// example as a field on the caller's Game struct, since it tracks whether a
+// drag is currently in progress and where the cursor was last seen.
+type DragPan struct {
+	dragging bool
+	lastX    int
+	lastY    int
+}
+
+// Update advances the drag-pan state machine. If pressed is true and this is
+// the first pressed call, the drag origin is recorded; on subsequent pressed
+// calls, the Camera is panned by the cursor delta. If pressed is false, any
+// in-progress drag ends.
+func (d *DragPan) Update(v *Viewport, pressed bool, cursorX, cursorY int) {
+	if !pressed {
+		d.dragging = false
+		return
+	}
+
+	if !d.dragging {
+		d.dragging = true
+		d.lastX, d.lastY = cursorX, cursorY
+		return
+	}
+
+	dx := float64(cursorX-d.lastX) / v.Camera.Zoom
+	dy := float64(cursorY-d.lastY) / v.Camera.Zoom
+	v.Camera.X -= dx
+	v.Camera.Y -= dy
+	d.lastX, d.lastY = cursorX, cursorY
+}
+
+// ScrollZoom adjusts the Camera's zoom by scroll, the vertical mouse wheel
+// delta as returned by ebiten.Wheel, clamping the result to [min, max].
+func ScrollZoom(c *Camera, scroll, min, max float64) {
+	c.Zoom += scroll * (c.Zoom / 7)
+	if c.Zoom < min {
+		c.Zoom = min
+	} else if c.Zoom > max {
+		c.Zoom = max
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera
+
+// CartesianToIso transforms cartesian coordinates into isometric coordinates
+// for a tileset with the given tile width and height.
+func CartesianToIso(x, y float64, tileWidth, tileHeight int) (float64, float64) {
+	ix := (x - y) * float64(tileWidth) / 2
+	iy := (x + y) * float64(tileHeight) / 2
+	return ix, iy
+}
+
+// IsoToCartesian transforms isometric coordinates into cartesian coordinates
+// for a tileset with the given tile width and height. It is the inverse of
+// CartesianToIso.
+func IsoToCartesian(x, y float64, tileWidth, tileHeight int) (float64, float64) {
+	hw, hh := float64(tileWidth)/2, float64(tileHeight)/2
+	cx := (x/hw + y/hh) / 2
+	cy := (y/hh - x/hw) / 2
+	return cx, cy
+}
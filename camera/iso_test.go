@@ -0,0 +1,48 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCartesianIsoRoundTrip(t *testing.T) {
+	const tileWidth, tileHeight = 64, 32
+
+	cases := []struct{ x, y float64 }{
+		{0, 0},
+		{1, 0},
+		{0, 1},
+		{3, 5},
+		{-2, 4},
+		{12.5, -7.25},
+	}
+
+	for _, c := range cases {
+		ix, iy := CartesianToIso(c.x, c.y, tileWidth, tileHeight)
+		gotX, gotY := IsoToCartesian(ix, iy, tileWidth, tileHeight)
+		if math.Abs(gotX-c.x) > 1e-9 || math.Abs(gotY-c.y) > 1e-9 {
+			t.Errorf("round trip for (%v, %v): got (%v, %v)", c.x, c.y, gotX, gotY)
+		}
+	}
+}
+
+func TestCartesianToIsoOrigin(t *testing.T) {
+	x, y := CartesianToIso(0, 0, 64, 32)
+	if x != 0 || y != 0 {
+		t.Errorf("CartesianToIso(0, 0, ...) = (%v, %v), want (0, 0)", x, y)
+	}
+}
@@ -20,42 +20,109 @@ package main
 import (
 	"fmt"
 	"image"
-	"math"
+	"image/color"
+	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/camera"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/tiled"
 )
 
+const (
+	levelWidth  = 25
+	levelHeight = 25
+	tileSize    = 32
+)
+
+// tileColors stands in for a real tileset image: this example has no art
+// assets, so each tile is just a flat-filled square, one color per GID.
+var tileColors = []color.RGBA{
+	{0x6a, 0x8c, 0x42, 0xff}, // grass
+	{0x8a, 0x6d, 0x3a, 0xff}, // dirt
+	{0x4a, 0x6f, 0x8c, 0xff}, // water
+	{0x9a, 0x9a, 0x9a, 0xff}, // stone
+}
+
+// newTilesetImage builds the atlas image backing the demo's only Tileset:
+// one tileSize x tileSize square per entry in tileColors, in a single row.
+func newTilesetImage() *ebiten.Image {
+	img := ebiten.NewImage(tileSize*len(tileColors), tileSize)
+	for i, c := range tileColors {
+		r := image.Rect(i*tileSize, 0, (i+1)*tileSize, tileSize)
+		img.SubImage(r).(*ebiten.Image).Fill(c)
+	}
+	return img
+}
+
+// newRandomLevel builds a w x h isometric tiled.Map backed by tileColors,
+// with occasional gaps left untiled.
+func newRandomLevel(w, h int) *tiled.Map {
+	data := make([]uint32, w*h)
+	for i := range data {
+		if rand.Intn(10) == 0 {
+			continue // leave a gap in the level
+		}
+		data[i] = uint32(1 + rand.Intn(len(tileColors)))
+	}
+
+	return &tiled.Map{
+		Orientation: tiled.OrientationIsometric,
+		Width:       w,
+		Height:      h,
+		TileWidth:   tileSize,
+		TileHeight:  tileSize / 2,
+		Tilesets: []*tiled.Tileset{{
+			Image:      "tiles",
+			TileWidth:  tileSize,
+			TileHeight: tileSize,
+			TileCount:  len(tileColors),
+			Columns:    len(tileColors),
+			FirstGID:   1,
+		}},
+		Layers: []*tiled.Layer{{
+			Type:    tiled.LayerTypeTile,
+			Visible: true,
+			Opacity: 1,
+			Width:   w,
+			Height:  h,
+			Data:    data,
+		}},
+	}
+}
+
 // Game is an isometric demo game.
 type Game struct {
-	w, h         int
-	currentLevel *Level
+	w, h int
 
-	camX, camY float64
-	camScale   float64
-	camScaleTo float64
+	tiles    *ebiten.Image
+	level    *tiled.Map
+	renderer *tiled.Renderer
 
-	mousePanX, mousePanY int
+	viewport *camera.Viewport
+	drag     camera.DragPan
 }
 
 // NewGame returns a new isometric demo Game.
 func NewGame() (*Game, error) {
-	l, err := NewLevel()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create new level: %s", err)
-	}
-
 	g := &Game{
-		currentLevel: l,
-		camScale:     2,
-		camScaleTo:   2,
-		mousePanX:    math.MinInt32,
-		mousePanY:    math.MinInt32,
+		tiles:    newTilesetImage(),
+		viewport: camera.NewViewport(camera.NewCamera(0, 0), 0, 0),
 	}
+	g.viewport.Camera.Zoom = 2
+	g.setLevel(newRandomLevel(levelWidth, levelHeight))
 	return g, nil
 }
 
+// setLevel installs level as the current level, along with a fresh
+// tiled.Renderer for it, since a Renderer is tied to the Map it was built
+// for.
+func (g *Game) setLevel(level *tiled.Map) {
+	g.level = level
+	g.renderer = tiled.NewRenderer(level, tiled.TilesetImages{"tiles": g.tiles})
+}
+
 // Update reads current user input and updates the Game state.
 func (g *Game) Update() error {
 	// Update target zoom level.
@@ -72,72 +139,34 @@ func (g *Game) Update() error {
 			scrollY = 1
 		}
 	}
-	g.camScaleTo += scrollY * (g.camScaleTo / 7)
-
-	// Clamp target zoom level.
-	if g.camScaleTo < 0.01 {
-		g.camScaleTo = 0.01
-	} else if g.camScaleTo > 100 {
-		g.camScaleTo = 100
-	}
-
-	// Smooth zoom transition.
-	div := 10.0
-	if g.camScaleTo > g.camScale {
-		g.camScale += (g.camScaleTo - g.camScale) / div
-	} else if g.camScaleTo < g.camScale {
-		g.camScale -= (g.camScale - g.camScaleTo) / div
-	}
+	camera.ScrollZoom(g.viewport.Camera, scrollY, 0.01, 100)
 
 	// Pan camera via keyboard.
-	pan := 7.0 / g.camScale
+	pan := 7.0 / g.viewport.Camera.Zoom
 	if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
-		g.camX -= pan
+		g.viewport.Camera.X -= pan
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
-		g.camX += pan
+		g.viewport.Camera.X += pan
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
-		g.camY -= pan
+		g.viewport.Camera.Y += pan
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
-		g.camY += pan
+		g.viewport.Camera.Y -= pan
 	}
 	// Pan camera via mouse.
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
-		if g.mousePanX == math.MinInt32 && g.mousePanY == math.MinInt32 {
-			g.mousePanX, g.mousePanY = ebiten.CursorPosition()
-		} else {
-			x, y := ebiten.CursorPosition()
-			dx, dy := float64(g.mousePanX-x)*(pan/100), float64(g.mousePanY-y)*(pan/100)
-			g.camX, g.camY = g.camX-dx, g.camY+dy
-		}
-	} else if g.mousePanX != math.MinInt32 || g.mousePanY != math.MinInt32 {
-		g.mousePanX, g.mousePanY = math.MinInt32, math.MinInt32
-	}
+	x, y := ebiten.CursorPosition()
+	g.drag.Update(g.viewport, ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight), x, y)
 
 	// Clamp camera position.
-	worldWidth := float64(g.currentLevel.w * g.currentLevel.tileSize / 2)
-	worldHeight := float64(g.currentLevel.h * g.currentLevel.tileSize / 2)
-	if g.camX < worldWidth*-1 {
-		g.camX = worldWidth * -1
-	} else if g.camX > worldWidth {
-		g.camX = worldWidth
-	}
-	if g.camY < worldHeight*-1 {
-		g.camY = worldHeight * -1
-	} else if g.camY > 0 {
-		g.camY = 0
-	}
+	worldWidth := float64(g.level.Width * tileSize / 2)
+	worldHeight := float64(g.level.Height * tileSize / 2)
+	g.viewport.ClampToBounds(-worldWidth, 0, worldWidth, worldHeight)
 
 	// Randomize level.
 	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
-		l, err := NewLevel()
-		if err != nil {
-			return fmt.Errorf("failed to create new level: %s", err)
-		}
-
-		g.currentLevel = l
+		g.setLevel(newRandomLevel(levelWidth, levelHeight))
 	}
 
 	return nil
@@ -145,13 +174,12 @@ func (g *Game) Update() error {
 
 // Draw draws the Game on the screen.
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Render level.
-	g.renderLevel(screen)
+	g.renderer.Draw(screen, g.viewport)
 
 	// Print game info.
 	debugBox := image.NewRGBA(image.Rect(0, 0, g.w, 200))
 	debugImg := ebiten.NewImageFromImage(debugBox)
-	ebitenutil.DebugPrint(debugImg, fmt.Sprintf("KEYS WASD EC R\nFPS  %0.0f\nTPS  %0.0f\nSCA  %0.2f\nPOS  %0.0f,%0.0f", ebiten.CurrentFPS(), ebiten.CurrentTPS(), g.camScale, g.camX, g.camY))
+	ebitenutil.DebugPrint(debugImg, fmt.Sprintf("KEYS WASD EC R\nFPS  %0.0f\nTPS  %0.0f\nSCA  %0.2f\nPOS  %0.0f,%0.0f", ebiten.CurrentFPS(), ebiten.CurrentTPS(), g.viewport.Camera.Zoom, g.viewport.Camera.X, g.viewport.Camera.Y))
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(3, 0)
 	op.GeoM.Scale(2, 2)
@@ -162,61 +190,6 @@ func (g *Game) Draw(screen *ebiten.Image) {
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	s := ebiten.DeviceScaleFactor()
 	g.w, g.h = int(s*float64(outsideWidth)), int(s*float64(outsideHeight))
+	g.viewport.Width, g.viewport.Height = g.w, g.h
 	return g.w, g.h
 }
-
-// cartesianToIso transforms cartesian coordinates into isometric coordinates.
-func (g *Game) cartesianToIso(x, y float64) (float64, float64) {
-	tileSize := g.currentLevel.tileSize
-	ix := (x - y) * float64(tileSize/2)
-	iy := (x + y) * float64(tileSize/4)
-	return ix, iy
-}
-
-/*
-This function might be useful for those who want to modify this example.
-
-// isoToCartesian transforms isometric coordinates into cartesian coordinates.
-func (g *Game) isoToCartesian(x, y float64) (float64, float64) {
-	tileSize := g.currentLevel.tileSize
-	cx := (x/float64(tileSize/2) + y/float64(tileSize/4)) / 2
-	cy := (y/float64(tileSize/4) - (x / float64(tileSize/2))) / 2
-	return cx, cy
-}
-*/
-
-// renderLevel draws the current Level on the screen.
-func (g *Game) renderLevel(screen *ebiten.Image) {
-	op := &ebiten.DrawImageOptions{}
-
-	var t *Tile
-	for y := 0; y < g.currentLevel.h; y++ {
-		for x := 0; x < g.currentLevel.w; x++ {
-			xi, yi := g.cartesianToIso(float64(x), float64(y))
-
-			// Skip drawing off-screen tiles.
-			padding := float64(g.currentLevel.tileSize) * g.camScale
-			drawX, drawY := ((xi-g.camX)*g.camScale)+float64(g.w/2.0), ((yi+g.camY)*g.camScale)+float64(g.h/2.0)
-			if drawX+padding < 0 || drawY+padding < 0 || drawX > float64(g.w) || drawY > float64(g.h) {
-				continue
-			}
-
-			t = g.currentLevel.tiles[y][x]
-			if t == nil {
-				continue // No tile at this position.
-			}
-
-			op.GeoM.Reset()
-			// Move to current isometric position.
-			op.GeoM.Translate(xi, yi)
-			// Translate camera position.
-			op.GeoM.Translate(-g.camX, g.camY)
-			// Zoom.
-			op.GeoM.Scale(g.camScale, g.camScale)
-			// Center.
-			op.GeoM.Translate(float64(g.w/2.0), float64(g.h/2.0))
-
-			t.Draw(screen, op)
-		}
-	}
-}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package builtinshader provides Kage shader sources that are shipped as part of ebiten
+// itself, rather than authored by the game.
+package builtinshader
+
+import "image"
+
+// EASUSource is the Kage source for AMD FidelityFX Super Resolution 1.0's
+// edge-adaptive spatial upsampling (EASU) stage.
+//
+// The uniform Con0 must be filled in with the value returned by
+// EASUConstants before the shader is used; it reprojects an output pixel
+// into input-texel space without a division in the per-pixel hot path. The
+// shader samples input texels directly in pixel units (via
+// imageSrc0UnsafeAt), so unlike AMD's reference implementation it needs no
+// further reciprocal constants.
+const EASUSource = `//kage:unit pixels
+
+package main
+
+var Con0 vec4
+
+func luma(c vec3) float {
+	return 0.5*c.g + 0.5*(0.75*c.r+0.25*c.b)
+}
+
+// easuWeight evaluates the Lanczos-2 approximation used by EASU:
+//
+//	w(x) = (25/16*(2/5*x^2-1)^2 - (25/16-1)) * ((1/4)*x^2-1)^2, clipped to x^2 <= 4
+//
+// d2 is x^2, i.e. the (possibly anisotropically warped) squared tap distance.
+func easuWeight(d2 float) float {
+	if d2 > 4.0 {
+		return 0
+	}
+	a := (2.0/5.0)*d2 - 1.0
+	b := (1.0/4.0)*d2 - 1.0
+	return (25.0/16.0*a*a - (25.0/16.0 - 1.0)) * b * b
+}
+
+// easuTap weights a single tap of the 4x4 neighborhood. The offset from the
+// sample point is projected onto the edge direction ndir and its
+// perpendicular pdir, and the perpendicular axis is scaled by stretch; this
+// elongates the kernel along detected edges instead of sampling an
+// isotropic circle, which is what makes EASU anisotropic.
+func easuTap(ndir, pdir vec2, stretch float, off, pixel vec2) float {
+	v := off - pixel
+	vx := dot(v, ndir)
+	vy := dot(v, pdir) * stretch
+	return easuWeight(vx*vx + vy*vy)
+}
+
+func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
+	pos := position.xy - imageDstOrigin()
+
+	// Reproject the output pixel into input-texel space using the precomputed ratios.
+	srcPos := pos*Con0.xy + Con0.zw
+
+	// Sample the immediate 2x2 quad to derive a single luma gradient; the
+	// quadrants of the 4x4 neighborhood are not analyzed separately.
+	p0 := srcPos - vec2(0.5, 0.5)
+	c00 := imageSrc0UnsafeAt(p0 + vec2(0, 0)).rgb
+	c10 := imageSrc0UnsafeAt(p0 + vec2(1, 0)).rgb
+	c01 := imageSrc0UnsafeAt(p0 + vec2(0, 1)).rgb
+	c11 := imageSrc0UnsafeAt(p0 + vec2(1, 1)).rgb
+
+	l00 := luma(c00)
+	l10 := luma(c10)
+	l01 := luma(c01)
+	l11 := luma(c11)
+
+	// Directional feature vector derived from luma deltas across the quad:
+	// the luma gradient rotated by 90 degrees so that it runs along the
+	// edge. This is the edge direction the resampling kernel stretches along.
+	grad := vec2(l10+l11-l00-l01, l01+l11-l00-l10)
+	dir := vec2(-grad.y, grad.x)
+	dirLen := length(dir)
+
+	var ndir vec2
+	if dirLen > 1e-5 {
+		ndir = dir / dirLen
+	} else {
+		ndir = vec2(1, 0)
+	}
+	pdir := vec2(-ndir.y, ndir.x)
+
+	// stretch compresses the kernel across the edge (the perpendicular
+	// axis) in proportion to edge strength: a flat region gets an
+	// isotropic kernel (stretch == 1), a strong edge gets a kernel
+	// elongated along it (stretch towards 2).
+	stretch := clamp(1.0+dirLen*2.0, 1.0, 2.0)
+
+	var sum vec3
+	var wsum float
+	lo := c00
+	hi := c00
+	for oy := -1; oy <= 2; oy++ {
+		for ox := -1; ox <= 2; ox++ {
+			off := p0 + vec2(float(ox), float(oy))
+			c := imageSrc0UnsafeAt(off).rgb
+			w := easuTap(ndir, pdir, stretch, off, srcPos)
+			sum += c * w
+			wsum += w
+			lo = min(lo, c)
+			hi = max(hi, c)
+		}
+	}
+
+	out := sum / max(wsum, 1e-5)
+	out = clamp(out, lo, hi)
+	return vec4(out, color.a)
+}
+`
+
+// RCASSource is the Kage source for FSR's optional robust contrast-adaptive
+// sharpening (RCAS) pass, meant to run after EASUSource.
+const RCASSource = `//kage:unit pixels
+
+package main
+
+// Sharpness is 0 for maximum sharpening and larger values for less.
+var Sharpness float
+
+func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
+	c := imageSrc0At(texCoord).rgb
+	n := imageSrc0At(texCoord + vec2(0, -1)).rgb
+	s := imageSrc0At(texCoord + vec2(0, 1)).rgb
+	w := imageSrc0At(texCoord + vec2(-1, 0)).rgb
+	e := imageSrc0At(texCoord + vec2(1, 0)).rgb
+
+	lo := min(min(min(n, s), min(w, e)), c)
+	hi := max(max(max(n, s), max(w, e)), c)
+
+	peak := (1.0 - 0.25*Sharpness)
+	amp := (min(lo.g, 2.0-hi.g) / max(hi.g, 1e-5)) * peak
+
+	out := c + (c*4.0-n-s-w-e)*amp
+	return vec4(out, color.a)
+}
+`
+
+// EASUConstants computes the Con0 uniform vector that parameterizes
+// EASUSource for a given input and output resolution, mirroring the
+// reprojection term of AMD's FsrEasuCon reference implementation. Computing
+// it once on the CPU lets the shader do no division per pixel.
+func EASUConstants(inputSize, outputSize image.Point) (con0 [4]float32) {
+	iw, ih := float32(inputSize.X), float32(inputSize.Y)
+	ow, oh := float32(outputSize.X), float32(outputSize.Y)
+
+	return [4]float32{iw / ow, ih / oh, 0.5*iw/ow - 0.5, 0.5*ih/oh - 0.5}
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader
+
+import (
+	"image"
+	"testing"
+)
+
+func TestEASUConstantsIdentityScale(t *testing.T) {
+	con0 := EASUConstants(image.Pt(1920, 1080), image.Pt(1920, 1080))
+
+	if got, want := con0[0], float32(1); got != want {
+		t.Errorf("con0.x: got %v, want %v", got, want)
+	}
+	if got, want := con0[1], float32(1); got != want {
+		t.Errorf("con0.y: got %v, want %v", got, want)
+	}
+}
+
+func TestEASUConstantsUpscale(t *testing.T) {
+	in := image.Pt(1280, 720)
+	out := image.Pt(1920, 1080)
+	con0 := EASUConstants(in, out)
+
+	wantX := float32(in.X) / float32(out.X)
+	wantY := float32(in.Y) / float32(out.Y)
+	if con0[0] != wantX {
+		t.Errorf("con0.x: got %v, want %v", con0[0], wantX)
+	}
+	if con0[1] != wantY {
+		t.Errorf("con0.y: got %v, want %v", con0[1], wantY)
+	}
+	if got, want := con0[2], 0.5*wantX-0.5; got != want {
+		t.Errorf("con0.z: got %v, want %v", got, want)
+	}
+}
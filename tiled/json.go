@@ -0,0 +1,263 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonProperty is Tiled's JSON representation of a custom property.
+type jsonProperty struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+func toProperties(ps []jsonProperty) Properties {
+	if len(ps) == 0 {
+		return nil
+	}
+	out := make(Properties, len(ps))
+	for _, p := range ps {
+		out[p.Name] = Property{
+			Name:  p.Name,
+			Type:  p.Type,
+			Value: fmt.Sprint(p.Value),
+		}
+	}
+	return out
+}
+
+type jsonFrame struct {
+	TileID   int `json:"tileid"`
+	Duration int `json:"duration"`
+}
+
+type jsonTile struct {
+	ID         int            `json:"id"`
+	Animation  []jsonFrame    `json:"animation"`
+	Properties []jsonProperty `json:"properties"`
+}
+
+type jsonTileset struct {
+	Name       string         `json:"name"`
+	Image      string         `json:"image"`
+	TileWidth  int            `json:"tilewidth"`
+	TileHeight int            `json:"tileheight"`
+	TileCount  int            `json:"tilecount"`
+	Columns    int            `json:"columns"`
+	Spacing    int            `json:"spacing"`
+	Margin     int            `json:"margin"`
+	FirstGID   int            `json:"firstgid"`
+	Tiles      []jsonTile     `json:"tiles"`
+	Properties []jsonProperty `json:"properties"`
+
+	// Source is set instead of the fields above when this tileset is an
+	// external reference (a .tsx/.tsj file) rather than embedded in the map.
+	// ebiten/tiled does not fetch external tilesets itself; load them with
+	// DecodeTileset and merge the result in before rendering.
+	Source string `json:"source"`
+}
+
+type jsonChunk struct {
+	X, Y, Width, Height int
+	Data                []uint32 `json:"data"`
+}
+
+type jsonObject struct {
+	ID         int            `json:"id"`
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	X          float64        `json:"x"`
+	Y          float64        `json:"y"`
+	Width      float64        `json:"width"`
+	Height     float64        `json:"height"`
+	Rotation   float64        `json:"rotation"`
+	Visible    bool           `json:"visible"`
+	GID        uint32         `json:"gid"`
+	Point      bool           `json:"point"`
+	Ellipse    bool           `json:"ellipse"`
+	Polygon    []Point        `json:"polygon"`
+	Polyline   []Point        `json:"polyline"`
+	Properties []jsonProperty `json:"properties"`
+}
+
+func (o jsonObject) toObject() Object {
+	shape := ObjectShapeRectangle
+	var points []Point
+	switch {
+	case o.GID != 0:
+		shape = ObjectShapeTile
+	case o.Point:
+		shape = ObjectShapePoint
+	case o.Ellipse:
+		shape = ObjectShapeEllipse
+	case len(o.Polygon) > 0:
+		shape = ObjectShapePolygon
+		points = o.Polygon
+	case len(o.Polyline) > 0:
+		shape = ObjectShapePolyline
+		points = o.Polyline
+	}
+	return Object{
+		ID:         o.ID,
+		Name:       o.Name,
+		Type:       o.Type,
+		Shape:      shape,
+		X:          o.X,
+		Y:          o.Y,
+		Width:      o.Width,
+		Height:     o.Height,
+		Rotation:   o.Rotation,
+		Visible:    o.Visible,
+		GID:        o.GID,
+		Points:     points,
+		Properties: toProperties(o.Properties),
+	}
+}
+
+type jsonLayer struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Visible    bool           `json:"visible"`
+	Opacity    float64        `json:"opacity"`
+	OffsetX    float64        `json:"offsetx"`
+	OffsetY    float64        `json:"offsety"`
+	Width      int            `json:"width"`
+	Height     int            `json:"height"`
+	Data       []uint32       `json:"data"`
+	Chunks     []jsonChunk    `json:"chunks"`
+	Objects    []jsonObject   `json:"objects"`
+	Image      string         `json:"image"`
+	Layers     []jsonLayer    `json:"layers"`
+	Properties []jsonProperty `json:"properties"`
+}
+
+func (l jsonLayer) toLayer() *Layer {
+	out := &Layer{
+		Name:       l.Name,
+		Type:       LayerType(l.Type),
+		Visible:    l.Visible,
+		Opacity:    l.Opacity,
+		OffsetX:    l.OffsetX,
+		OffsetY:    l.OffsetY,
+		Width:      l.Width,
+		Height:     l.Height,
+		Data:       l.Data,
+		Image:      l.Image,
+		Properties: toProperties(l.Properties),
+	}
+	for _, c := range l.Chunks {
+		out.Chunks = append(out.Chunks, Chunk{X: c.X, Y: c.Y, Width: c.Width, Height: c.Height, Data: c.Data})
+	}
+	for _, o := range l.Objects {
+		out.Objects = append(out.Objects, o.toObject())
+	}
+	for _, sub := range l.Layers {
+		out.Layers = append(out.Layers, sub.toLayer())
+	}
+	return out
+}
+
+type jsonMap struct {
+	Orientation   string         `json:"orientation"`
+	StaggerAxis   string         `json:"staggeraxis"`
+	Width         int            `json:"width"`
+	Height        int            `json:"height"`
+	TileWidth     int            `json:"tilewidth"`
+	TileHeight    int            `json:"tileheight"`
+	HexSideLength int            `json:"hexsidelength"`
+	Infinite      bool           `json:"infinite"`
+	Tilesets      []jsonTileset  `json:"tilesets"`
+	Layers        []jsonLayer    `json:"layers"`
+	Properties    []jsonProperty `json:"properties"`
+}
+
+// DecodeMap parses a Tiled JSON map (.tmj/.json) from r.
+//
+// External tilesets (tilesets referencing a "source" file rather than
+// embedding their data) are returned with only their FirstGID populated;
+// load them separately with DecodeTileset and copy in the remaining fields
+// before rendering.
+func DecodeMap(r io.Reader) (*Map, error) {
+	var jm jsonMap
+	if err := json.NewDecoder(r).Decode(&jm); err != nil {
+		return nil, fmt.Errorf("tiled: decoding map: %w", err)
+	}
+
+	m := &Map{
+		Orientation:   Orientation(jm.Orientation),
+		StaggerAxis:   StaggerAxis(jm.StaggerAxis),
+		Width:         jm.Width,
+		Height:        jm.Height,
+		TileWidth:     jm.TileWidth,
+		TileHeight:    jm.TileHeight,
+		HexSideLength: jm.HexSideLength,
+		Infinite:      jm.Infinite,
+		Properties:    toProperties(jm.Properties),
+	}
+	for _, jt := range jm.Tilesets {
+		m.Tilesets = append(m.Tilesets, jt.toTileset())
+	}
+	for _, jl := range jm.Layers {
+		m.Layers = append(m.Layers, jl.toLayer())
+	}
+	return m, nil
+}
+
+// DecodeTileset parses a standalone Tiled JSON tileset (.tsj/.json), as
+// referenced by an external tileset's Source.
+func DecodeTileset(r io.Reader) (*Tileset, error) {
+	var jt jsonTileset
+	if err := json.NewDecoder(r).Decode(&jt); err != nil {
+		return nil, fmt.Errorf("tiled: decoding tileset: %w", err)
+	}
+	return jt.toTileset(), nil
+}
+
+func (jt jsonTileset) toTileset() *Tileset {
+	ts := &Tileset{
+		Name:       jt.Name,
+		Image:      jt.Image,
+		TileWidth:  jt.TileWidth,
+		TileHeight: jt.TileHeight,
+		TileCount:  jt.TileCount,
+		Columns:    jt.Columns,
+		Spacing:    jt.Spacing,
+		Margin:     jt.Margin,
+		FirstGID:   jt.FirstGID,
+	}
+	for _, t := range jt.Tiles {
+		if len(t.Animation) > 0 {
+			if ts.Animations == nil {
+				ts.Animations = make(map[int]Animation)
+			}
+			var frames []Frame
+			for _, f := range t.Animation {
+				frames = append(frames, Frame{TileID: f.TileID, Duration: f.Duration})
+			}
+			ts.Animations[t.ID] = Animation{Frames: frames}
+		}
+		if len(t.Properties) > 0 {
+			if ts.Properties == nil {
+				ts.Properties = make(map[int]Properties)
+			}
+			ts.Properties[t.ID] = toProperties(t.Properties)
+		}
+	}
+	return ts
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+// LayerType distinguishes the kinds of layers a Map can contain.
+type LayerType string
+
+// The layer types Tiled supports.
+const (
+	LayerTypeTile   LayerType = "tilelayer"
+	LayerTypeObject LayerType = "objectgroup"
+	LayerTypeImage  LayerType = "imagelayer"
+	LayerTypeGroup  LayerType = "group"
+)
+
+// Chunk is one rectangular piece of an infinite Layer's tile data.
+type Chunk struct {
+	X, Y          int
+	Width, Height int
+	Data          []uint32 // raw GIDs, including flip flags; row-major
+}
+
+// Layer is a single layer of a Map: a grid of tile GIDs, a collection of
+// Objects, a single Image, or a Group of further layers, depending on Type.
+type Layer struct {
+	Name    string
+	Type    LayerType
+	Visible bool
+	Opacity float64
+	OffsetX float64
+	OffsetY float64
+
+	// Width and Height are in tiles, and are valid for LayerTypeTile.
+	Width, Height int
+
+	// Data holds raw GIDs, including flip flags, row-major, for a
+	// non-infinite LayerTypeTile layer. Use Map.TilesetFor and FlagsOf to
+	// interpret each entry.
+	Data []uint32
+
+	// Chunks holds the tile data for an infinite LayerTypeTile layer,
+	// instead of Data.
+	Chunks []Chunk
+
+	// Objects holds this layer's shapes, for LayerTypeObject.
+	Objects []Object
+
+	// Image is the source image path, for LayerTypeImage.
+	Image string
+
+	// Layers holds the nested layers, for LayerTypeGroup.
+	Layers []*Layer
+
+	Properties Properties
+}
+
+// ObjectShape is the kind of geometry an Object describes.
+type ObjectShape string
+
+// The shapes an Object layer's objects can take.
+const (
+	ObjectShapeRectangle ObjectShape = "rectangle"
+	ObjectShapeEllipse   ObjectShape = "ellipse"
+	ObjectShapePoint     ObjectShape = "point"
+	ObjectShapePolygon   ObjectShape = "polygon"
+	ObjectShapePolyline  ObjectShape = "polyline"
+	ObjectShapeTile      ObjectShape = "tile"
+	ObjectShapeText      ObjectShape = "text"
+)
+
+// Point is a 2D point in map pixel coordinates.
+type Point struct {
+	X, Y float64
+}
+
+// Object is a single shape placed on an object layer, such as a collision
+// box, a spawn point, or a path.
+type Object struct {
+	ID       int
+	Name     string
+	Type     string
+	Shape    ObjectShape
+	X, Y     float64
+	Width    float64
+	Height   float64
+	Rotation float64
+	Visible  bool
+
+	// GID is set when Shape is ObjectShapeTile, and is the raw tile GID the
+	// object renders as. Like Layer.Data, it carries flip flags in its top
+	// bits; use FlagsOf to decode them.
+	GID uint32
+
+	// Points holds the relative vertices of a polygon or polyline, for
+	// ObjectShapePolygon and ObjectShapePolyline.
+	Points []Point
+
+	Properties Properties
+}
@@ -0,0 +1,262 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/camera"
+)
+
+// TilesetImages supplies the decoded *ebiten.Image for each Tileset in a
+// Map, keyed by Tileset.Image, since decoding image files is outside the
+// scope of this package.
+type TilesetImages map[string]*ebiten.Image
+
+// Renderer draws a Map's layers to an *ebiten.Image through a
+// camera.Viewport. A Renderer keeps per-tile animation state, so reuse one
+// Renderer across a Map's lifetime rather than creating a new one per
+// frame.
+type Renderer struct {
+	Map    *Map
+	Images TilesetImages
+
+	// animState tracks each animated tile's current frame and the time left
+	// in it, keyed by (tileset, local tile ID). It is advanced once per Draw
+	// call by one tick's worth of time (1000/ebiten.CurrentTPS milliseconds),
+	// so animations run at their authored speed when Draw is called once per
+	// tick; a rendering frame rate that differs from the TPS is not
+	// compensated for.
+	animState map[animKey]*animState
+}
+
+type animKey struct {
+	tileset *Tileset
+	tileID  int
+}
+
+type animState struct {
+	frame     int
+	remaining float64 // remaining duration of the current frame, in milliseconds
+}
+
+// NewRenderer returns a new Renderer for m, drawing tile images supplied by
+// images.
+func NewRenderer(m *Map, images TilesetImages) *Renderer {
+	return &Renderer{
+		Map:       m,
+		Images:    images,
+		animState: make(map[animKey]*animState),
+	}
+}
+
+// Draw draws all visible layers of the Map to dst, as seen through
+// viewport. Tile flip/rotation flags and animated tiles are honored
+// automatically; object layers are not drawn and should be consumed
+// directly via Map.Layers for gameplay logic.
+func (r *Renderer) Draw(dst *ebiten.Image, viewport *camera.Viewport) {
+	r.advanceAnimations()
+
+	for _, l := range r.Map.Layers {
+		r.drawLayer(dst, viewport, l)
+	}
+}
+
+func (r *Renderer) advanceAnimations() {
+	tps := ebiten.CurrentTPS()
+	if tps <= 0 {
+		tps = 60
+	}
+	msPerTick := 1000 / tps
+
+	for _, ts := range r.Map.Tilesets {
+		for tileID, anim := range ts.Animations {
+			if len(anim.Frames) == 0 {
+				continue
+			}
+			key := animKey{ts, tileID}
+			st := r.animState[key]
+			if st == nil {
+				st = &animState{remaining: float64(anim.Frames[0].Duration)}
+				r.animState[key] = st
+			}
+			st.remaining -= msPerTick
+			for st.remaining <= 0 {
+				st.frame = (st.frame + 1) % len(anim.Frames)
+				st.remaining += float64(anim.Frames[st.frame].Duration)
+			}
+		}
+	}
+}
+
+// currentTileID resolves the local tile ID that should actually be drawn
+// for localID within ts, following its Animation if one is set.
+func (r *Renderer) currentTileID(ts *Tileset, localID int) int {
+	anim, ok := ts.Animations[localID]
+	if !ok || len(anim.Frames) == 0 {
+		return localID
+	}
+	st := r.animState[animKey{ts, localID}]
+	if st == nil {
+		return anim.Frames[0].TileID
+	}
+	return anim.Frames[st.frame].TileID
+}
+
+func (r *Renderer) drawLayer(dst *ebiten.Image, viewport *camera.Viewport, l *Layer) {
+	if !l.Visible {
+		return
+	}
+
+	switch l.Type {
+	case LayerTypeGroup:
+		for _, sub := range l.Layers {
+			r.drawLayer(dst, viewport, sub)
+		}
+	case LayerTypeTile:
+		r.drawTileLayer(dst, viewport, l)
+	}
+	// Object and image layers carry no ebiten.Image to blit by themselves;
+	// games consume Layer.Objects/Layer.Image directly.
+}
+
+func (r *Renderer) drawTileLayer(dst *ebiten.Image, viewport *camera.Viewport, l *Layer) {
+	if l.Width > 0 && l.Height > 0 && len(l.Data) > 0 {
+		r.drawTileGrid(dst, viewport, l, 0, 0, l.Width, l.Height, l.Data, l.OffsetX, l.OffsetY)
+		return
+	}
+	for _, c := range l.Chunks {
+		r.drawTileGrid(dst, viewport, l, c.X, c.Y, c.Width, c.Height, c.Data, l.OffsetX, l.OffsetY)
+	}
+}
+
+func (r *Renderer) drawTileGrid(dst *ebiten.Image, viewport *camera.Viewport, l *Layer, originX, originY, w, h int, data []uint32, offX, offY float64) {
+	m := r.Map
+	tw, th := m.TileWidth, m.TileHeight
+
+	for ty := 0; ty < h; ty++ {
+		for tx := 0; tx < w; tx++ {
+			gid := data[ty*w+tx]
+			if gid == 0 {
+				continue
+			}
+
+			ts, localID, ok := m.TilesetFor(gid)
+			if !ok {
+				continue
+			}
+			localID = r.currentTileID(ts, localID)
+
+			img := r.Images[ts.Image]
+			if img == nil {
+				continue
+			}
+
+			mapX, mapY := originX+tx, originY+ty
+
+			var wx, wy float64
+			switch m.Orientation {
+			case OrientationIsometric:
+				wx, wy = camera.CartesianToIso(float64(mapX), float64(mapY), tw, th)
+			case OrientationStaggered:
+				wx, wy = staggeredToWorld(mapX, mapY, tw, th, m.StaggerAxis)
+			case OrientationHexagonal:
+				wx, wy = hexToWorld(mapX, mapY, tw, th, m.HexSideLength, m.StaggerAxis)
+			default: // orthogonal
+				wx, wy = float64(mapX*tw), float64(mapY*th)
+			}
+			wx += offX
+			wy += offY
+
+			if !viewport.IsRectVisible(image.Rect(int(wx), int(wy), int(wx)+tw, int(wy)+th)) {
+				continue
+			}
+
+			op := &ebiten.DrawImageOptions{}
+			flags := FlagsOf(gid)
+			applyFlip(op, flags, tw, th)
+			op.GeoM.Translate(wx, wy)
+			viewport.Apply(op)
+
+			sx := (localID % ts.Columns) * ts.TileWidth
+			sy := (localID / ts.Columns) * ts.TileHeight
+			src := img.SubImage(image.Rect(sx, sy, sx+ts.TileWidth, sy+ts.TileHeight)).(*ebiten.Image)
+			dst.DrawImage(src, op)
+		}
+	}
+}
+
+// staggeredToWorld places tile (x, y) for a staggered map, offsetting
+// alternate rows or columns by half a tile along the stagger axis.
+func staggeredToWorld(x, y, tw, th int, axis StaggerAxis) (float64, float64) {
+	if axis == StaggerAxisX {
+		wx := float64(x*tw) / 2
+		wy := float64(y * th)
+		if x%2 != 0 {
+			wy += float64(th) / 2
+		}
+		return wx, wy
+	}
+	wx := float64(x * tw)
+	wy := float64(y*th) / 2
+	if y%2 != 0 {
+		wx += float64(tw) / 2
+	}
+	return wx, wy
+}
+
+// hexToWorld places tile (x, y) for a hexagonal map, following Tiled's own
+// layout: along the stagger axis, consecutive columns (or rows) are spaced
+// (tile size + hexSide)/2 pixels apart instead of a full tile, and alternate
+// columns (or rows) are shifted by half a tile along the other axis.
+func hexToWorld(x, y, tw, th, hexSide int, axis StaggerAxis) (float64, float64) {
+	if axis == StaggerAxisX {
+		colWidth := (float64(tw) + float64(hexSide)) / 2
+		wx := float64(x) * colWidth
+		wy := float64(y * th)
+		if x%2 != 0 {
+			wy += float64(th) / 2
+		}
+		return wx, wy
+	}
+	rowHeight := (float64(th) + float64(hexSide)) / 2
+	wx := float64(x * tw)
+	wy := float64(y) * rowHeight
+	if y%2 != 0 {
+		wx += float64(tw) / 2
+	}
+	return wx, wy
+}
+
+func applyFlip(op *ebiten.DrawImageOptions, flags TileFlags, tw, th int) {
+	if flags.FlippedDiagonally {
+		// Tiled's diagonal flag transposes the tile (reflects it across its
+		// main diagonal), not just rotating it. A 90 degree rotation alone
+		// has determinant +1 and only rotates the tile; composing it with a
+		// horizontal flip (determinant -1) yields the transpose (x,y) ->
+		// (y,x) with no extra translation needed for a square tile.
+		op.GeoM.Rotate(1.5707963267948966) // 90 degrees
+		op.GeoM.Scale(-1, 1)
+	}
+	if flags.FlippedHorizontally {
+		op.GeoM.Scale(-1, 1)
+		op.GeoM.Translate(float64(tw), 0)
+	}
+	if flags.FlippedVertically {
+		op.GeoM.Scale(1, -1)
+		op.GeoM.Translate(0, float64(th))
+	}
+}
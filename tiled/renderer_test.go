@@ -0,0 +1,84 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TestApplyFlip checks applyFlip against Tiled's documented GID flip flags
+// for all 8 H/V/D combinations, using an asymmetric point so that a missed
+// axis swap or mirror shows up as a mismatch.
+func TestApplyFlip(t *testing.T) {
+	const s = 16 // tw == th; the diagonal flag only makes sense for square tiles
+	px, py := 4.0, 11.0
+
+	tests := []struct {
+		name         string
+		flags        TileFlags
+		wantX, wantY float64
+	}{
+		{"none", TileFlags{}, px, py},
+		{"H", TileFlags{FlippedHorizontally: true}, s - px, py},
+		{"V", TileFlags{FlippedVertically: true}, px, s - py},
+		{"HV", TileFlags{FlippedHorizontally: true, FlippedVertically: true}, s - px, s - py},
+		{"D", TileFlags{FlippedDiagonally: true}, py, px},
+		{"DH", TileFlags{FlippedDiagonally: true, FlippedHorizontally: true}, s - py, px},
+		{"DV", TileFlags{FlippedDiagonally: true, FlippedVertically: true}, py, s - px},
+		{"DHV", TileFlags{FlippedDiagonally: true, FlippedHorizontally: true, FlippedVertically: true}, s - py, s - px},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &ebiten.DrawImageOptions{}
+			applyFlip(op, tt.flags, s, s)
+			gotX, gotY := op.GeoM.Apply(px, py)
+			if math.Abs(gotX-tt.wantX) > 1e-9 || math.Abs(gotY-tt.wantY) > 1e-9 {
+				t.Errorf("flags %+v: got (%v, %v), want (%v, %v)", tt.flags, gotX, gotY, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+// TestHexToWorld checks that hexagonal tile placement actually staggers by
+// half the row/column spacing, rather than falling back to an unstaggered
+// orthogonal grid.
+func TestHexToWorld(t *testing.T) {
+	const tw, th, hexSide = 32, 32, 16
+
+	tests := []struct {
+		name         string
+		x, y         int
+		axis         StaggerAxis
+		wantX, wantY float64
+	}{
+		{"x-axis even column", 0, 3, StaggerAxisX, 0, 96},
+		{"x-axis odd column staggers y", 1, 3, StaggerAxisX, 24, 112},
+		{"y-axis even row", 3, 0, StaggerAxisY, 96, 0},
+		{"y-axis odd row staggers x", 3, 1, StaggerAxisY, 112, 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotX, gotY := hexToWorld(tt.x, tt.y, tw, th, hexSide, tt.axis)
+			if gotX != tt.wantX || gotY != tt.wantY {
+				t.Errorf("hexToWorld(%d, %d, axis=%v): got (%v, %v), want (%v, %v)", tt.x, tt.y, tt.axis, gotX, gotY, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
@@ -0,0 +1,531 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type xmlProperty struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type xmlProperties struct {
+	Property []xmlProperty `xml:"property"`
+}
+
+func (p *xmlProperties) toProperties() Properties {
+	if p == nil || len(p.Property) == 0 {
+		return nil
+	}
+	out := make(Properties, len(p.Property))
+	for _, prop := range p.Property {
+		out[prop.Name] = Property{Name: prop.Name, Type: prop.Type, Value: prop.Value}
+	}
+	return out
+}
+
+type xmlFrame struct {
+	TileID   int `xml:"tileid,attr"`
+	Duration int `xml:"duration,attr"`
+}
+
+type xmlTile struct {
+	ID        int `xml:"id,attr"`
+	Animation *struct {
+		Frame []xmlFrame `xml:"frame"`
+	} `xml:"animation"`
+	Properties *xmlProperties `xml:"properties"`
+}
+
+type xmlImage struct {
+	Source string `xml:"source,attr"`
+}
+
+type xmlTileset struct {
+	Name       string    `xml:"name,attr"`
+	TileWidth  int       `xml:"tilewidth,attr"`
+	TileHeight int       `xml:"tileheight,attr"`
+	TileCount  int       `xml:"tilecount,attr"`
+	Columns    int       `xml:"columns,attr"`
+	Spacing    int       `xml:"spacing,attr"`
+	Margin     int       `xml:"margin,attr"`
+	FirstGID   int       `xml:"firstgid,attr"`
+	Source     string    `xml:"source,attr"`
+	Image      xmlImage  `xml:"image"`
+	Tiles      []xmlTile `xml:"tile"`
+}
+
+func (xt xmlTileset) toTileset() *Tileset {
+	ts := &Tileset{
+		Name:       xt.Name,
+		Image:      xt.Image.Source,
+		TileWidth:  xt.TileWidth,
+		TileHeight: xt.TileHeight,
+		TileCount:  xt.TileCount,
+		Columns:    xt.Columns,
+		Spacing:    xt.Spacing,
+		Margin:     xt.Margin,
+		FirstGID:   xt.FirstGID,
+	}
+	for _, t := range xt.Tiles {
+		if t.Animation != nil && len(t.Animation.Frame) > 0 {
+			if ts.Animations == nil {
+				ts.Animations = make(map[int]Animation)
+			}
+			var frames []Frame
+			for _, f := range t.Animation.Frame {
+				frames = append(frames, Frame{TileID: f.TileID, Duration: f.Duration})
+			}
+			ts.Animations[t.ID] = Animation{Frames: frames}
+		}
+		if props := t.Properties.toProperties(); len(props) > 0 {
+			if ts.Properties == nil {
+				ts.Properties = make(map[int]Properties)
+			}
+			ts.Properties[t.ID] = props
+		}
+	}
+	return ts
+}
+
+// xmlTileElem is a single <tile gid="n"/> child, used for layer and chunk
+// data when no encoding attribute is given (Tiled's default, uncompressed
+// per-tile XML format).
+type xmlTileElem struct {
+	GID uint32 `xml:"gid,attr"`
+}
+
+type xmlChunk struct {
+	X        int           `xml:"x,attr"`
+	Y        int           `xml:"y,attr"`
+	Width    int           `xml:"width,attr"`
+	Height   int           `xml:"height,attr"`
+	Tile     []xmlTileElem `xml:"tile"`
+	CharData string        `xml:",chardata"`
+}
+
+type xmlData struct {
+	Encoding    string        `xml:"encoding,attr"`
+	Compression string        `xml:"compression,attr"`
+	Chunk       []xmlChunk    `xml:"chunk"`
+	Tile        []xmlTileElem `xml:"tile"`
+	CharData    string        `xml:",chardata"`
+}
+
+// decodeGIDs interprets charData/tiles as GIDs according to encoding and
+// compression, as found on a TMX <data> element. tiles is used only when
+// encoding is empty (Tiled's default per-tile XML format); charData is used
+// for "csv" and "base64" (optionally gzip- or zlib-compressed).
+func decodeGIDs(encoding, compression, charData string, tiles []xmlTileElem) ([]uint32, error) {
+	switch encoding {
+	case "":
+		gids := make([]uint32, len(tiles))
+		for i, t := range tiles {
+			gids[i] = t.GID
+		}
+		return gids, nil
+	case "csv":
+		return parseCSVGIDs(charData)
+	case "base64":
+		return decodeBase64GIDs(charData, compression)
+	default:
+		return nil, fmt.Errorf("tiled: unsupported layer data encoding %q", encoding)
+	}
+}
+
+func parseCSVGIDs(s string) ([]uint32, error) {
+	rd := csv.NewReader(strings.NewReader(strings.TrimSpace(s)))
+	rd.FieldsPerRecord = -1
+	var out []uint32
+	for {
+		rec, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range rec {
+			f = strings.TrimSpace(f)
+			if f == "" {
+				continue
+			}
+			v, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, uint32(v))
+		}
+	}
+	return out, nil
+}
+
+// decodeBase64GIDs decodes base64 layer chardata, optionally decompressing
+// it with gzip or zlib (Tiled's two supported compression modes for base64
+// data besides zstd, which is not supported since it has no Go standard
+// library implementation), then reinterprets the result as a stream of
+// little-endian uint32 GIDs.
+func decodeBase64GIDs(s, compression string) ([]uint32, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("tiled: decoding base64 layer data: %w", err)
+	}
+
+	r := io.Reader(bytes.NewReader(raw))
+	switch compression {
+	case "":
+	case "gzip":
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("tiled: decompressing gzip layer data: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	case "zlib":
+		zr, err := zlib.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("tiled: decompressing zlib layer data: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return nil, fmt.Errorf("tiled: unsupported layer data compression %q", compression)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("tiled: reading layer data: %w", err)
+	}
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("tiled: layer data is %d bytes, not a multiple of 4", len(data))
+	}
+	gids := make([]uint32, len(data)/4)
+	for i := range gids {
+		gids[i] = binary.LittleEndian.Uint32(data[i*4:])
+	}
+	return gids, nil
+}
+
+type xmlObject struct {
+	ID       int       `xml:"id,attr"`
+	Name     string    `xml:"name,attr"`
+	Type     string    `xml:"type,attr"`
+	X        float64   `xml:"x,attr"`
+	Y        float64   `xml:"y,attr"`
+	Width    float64   `xml:"width,attr"`
+	Height   float64   `xml:"height,attr"`
+	Rotation float64   `xml:"rotation,attr"`
+	GID      uint32    `xml:"gid,attr"`
+	Visible  *int      `xml:"visible,attr"`
+	Point    *struct{} `xml:"point"`
+	Ellipse  *struct{} `xml:"ellipse"`
+	Polygon  *struct {
+		Points string `xml:"points,attr"`
+	} `xml:"polygon"`
+	Polyline *struct {
+		Points string `xml:"points,attr"`
+	} `xml:"polyline"`
+	Properties *xmlProperties `xml:"properties"`
+}
+
+func parsePoints(s string) []Point {
+	var pts []Point
+	for _, pair := range strings.Fields(s) {
+		xy := strings.SplitN(pair, ",", 2)
+		if len(xy) != 2 {
+			continue
+		}
+		x, _ := strconv.ParseFloat(xy[0], 64)
+		y, _ := strconv.ParseFloat(xy[1], 64)
+		pts = append(pts, Point{X: x, Y: y})
+	}
+	return pts
+}
+
+func (xo xmlObject) toObject() Object {
+	shape := ObjectShapeRectangle
+	var points []Point
+	switch {
+	case xo.GID != 0:
+		shape = ObjectShapeTile
+	case xo.Point != nil:
+		shape = ObjectShapePoint
+	case xo.Ellipse != nil:
+		shape = ObjectShapeEllipse
+	case xo.Polygon != nil:
+		shape = ObjectShapePolygon
+		points = parsePoints(xo.Polygon.Points)
+	case xo.Polyline != nil:
+		shape = ObjectShapePolyline
+		points = parsePoints(xo.Polyline.Points)
+	}
+	visible := true
+	if xo.Visible != nil {
+		visible = *xo.Visible != 0
+	}
+	return Object{
+		ID:         xo.ID,
+		Name:       xo.Name,
+		Type:       xo.Type,
+		Shape:      shape,
+		X:          xo.X,
+		Y:          xo.Y,
+		Width:      xo.Width,
+		Height:     xo.Height,
+		Rotation:   xo.Rotation,
+		Visible:    visible,
+		GID:        xo.GID,
+		Points:     points,
+		Properties: xo.Properties.toProperties(),
+	}
+}
+
+type xmlLayer struct {
+	Name       string         `xml:"name,attr"`
+	Visible    *int           `xml:"visible,attr"`
+	Opacity    *float64       `xml:"opacity,attr"`
+	OffsetX    float64        `xml:"offsetx,attr"`
+	OffsetY    float64        `xml:"offsety,attr"`
+	Width      int            `xml:"width,attr"`
+	Height     int            `xml:"height,attr"`
+	Data       *xmlData       `xml:"data"`
+	Properties *xmlProperties `xml:"properties"`
+}
+
+type xmlObjectGroup struct {
+	Name       string         `xml:"name,attr"`
+	Visible    *int           `xml:"visible,attr"`
+	Opacity    *float64       `xml:"opacity,attr"`
+	Object     []xmlObject    `xml:"object"`
+	Properties *xmlProperties `xml:"properties"`
+}
+
+type xmlImageLayer struct {
+	Name       string         `xml:"name,attr"`
+	Visible    *int           `xml:"visible,attr"`
+	Opacity    *float64       `xml:"opacity,attr"`
+	Image      xmlImage       `xml:"image"`
+	Properties *xmlProperties `xml:"properties"`
+}
+
+type xmlGroup struct {
+	Name        string           `xml:"name,attr"`
+	Visible     *int             `xml:"visible,attr"`
+	Layer       []xmlLayer       `xml:"layer"`
+	ObjectGroup []xmlObjectGroup `xml:"objectgroup"`
+	ImageLayer  []xmlImageLayer  `xml:"imagelayer"`
+	Group       []xmlGroup       `xml:"group"`
+	Properties  *xmlProperties   `xml:"properties"`
+}
+
+func boolAttr(v *int, def bool) bool {
+	if v == nil {
+		return def
+	}
+	return *v != 0
+}
+
+func opacityAttr(v *float64) float64 {
+	if v == nil {
+		return 1
+	}
+	return *v
+}
+
+func (xl xmlLayer) toLayer() (*Layer, error) {
+	out := &Layer{
+		Name:       xl.Name,
+		Type:       LayerTypeTile,
+		Visible:    boolAttr(xl.Visible, true),
+		Opacity:    opacityAttr(xl.Opacity),
+		OffsetX:    xl.OffsetX,
+		OffsetY:    xl.OffsetY,
+		Width:      xl.Width,
+		Height:     xl.Height,
+		Properties: xl.Properties.toProperties(),
+	}
+	if xl.Data == nil {
+		return out, nil
+	}
+	if len(xl.Data.Chunk) > 0 {
+		for _, c := range xl.Data.Chunk {
+			gids, err := decodeGIDs(xl.Data.Encoding, xl.Data.Compression, c.CharData, c.Tile)
+			if err != nil {
+				return nil, fmt.Errorf("tiled: parsing chunk data: %w", err)
+			}
+			out.Chunks = append(out.Chunks, Chunk{X: c.X, Y: c.Y, Width: c.Width, Height: c.Height, Data: gids})
+		}
+		return out, nil
+	}
+	gids, err := decodeGIDs(xl.Data.Encoding, xl.Data.Compression, xl.Data.CharData, xl.Data.Tile)
+	if err != nil {
+		return nil, fmt.Errorf("tiled: parsing layer data: %w", err)
+	}
+	out.Data = gids
+	return out, nil
+}
+
+func (xg xmlGroup) toLayer() (*Layer, error) {
+	out := &Layer{
+		Name:       xg.Name,
+		Type:       LayerTypeGroup,
+		Visible:    boolAttr(xg.Visible, true),
+		Opacity:    1,
+		Properties: xg.Properties.toProperties(),
+	}
+	for _, l := range xg.Layer {
+		sub, err := l.toLayer()
+		if err != nil {
+			return nil, err
+		}
+		out.Layers = append(out.Layers, sub)
+	}
+	for _, og := range xg.ObjectGroup {
+		sub := &Layer{
+			Name:       og.Name,
+			Type:       LayerTypeObject,
+			Visible:    boolAttr(og.Visible, true),
+			Opacity:    opacityAttr(og.Opacity),
+			Properties: og.Properties.toProperties(),
+		}
+		for _, o := range og.Object {
+			sub.Objects = append(sub.Objects, o.toObject())
+		}
+		out.Layers = append(out.Layers, sub)
+	}
+	for _, il := range xg.ImageLayer {
+		out.Layers = append(out.Layers, &Layer{
+			Name:       il.Name,
+			Type:       LayerTypeImage,
+			Visible:    boolAttr(il.Visible, true),
+			Opacity:    opacityAttr(il.Opacity),
+			Image:      il.Image.Source,
+			Properties: il.Properties.toProperties(),
+		})
+	}
+	for _, g := range xg.Group {
+		sub, err := g.toLayer()
+		if err != nil {
+			return nil, err
+		}
+		out.Layers = append(out.Layers, sub)
+	}
+	return out, nil
+}
+
+type xmlMap struct {
+	XMLName       xml.Name         `xml:"map"`
+	Orientation   string           `xml:"orientation,attr"`
+	StaggerAxis   string           `xml:"staggeraxis,attr"`
+	Width         int              `xml:"width,attr"`
+	Height        int              `xml:"height,attr"`
+	TileWidth     int              `xml:"tilewidth,attr"`
+	TileHeight    int              `xml:"tileheight,attr"`
+	HexSideLength int              `xml:"hexsidelength,attr"`
+	Infinite      int              `xml:"infinite,attr"`
+	Tileset       []xmlTileset     `xml:"tileset"`
+	Layer         []xmlLayer       `xml:"layer"`
+	ObjectGroup   []xmlObjectGroup `xml:"objectgroup"`
+	ImageLayer    []xmlImageLayer  `xml:"imagelayer"`
+	Group         []xmlGroup       `xml:"group"`
+	Properties    *xmlProperties   `xml:"properties"`
+}
+
+// DecodeTMX parses a Tiled TMX map (XML) from r.
+//
+// As with DecodeMap, external tilesets are returned with only FirstGID
+// populated; load them with DecodeTSX and merge the result in.
+func DecodeTMX(r io.Reader) (*Map, error) {
+	var xm xmlMap
+	if err := xml.NewDecoder(r).Decode(&xm); err != nil {
+		return nil, fmt.Errorf("tiled: decoding TMX: %w", err)
+	}
+
+	m := &Map{
+		Orientation:   Orientation(xm.Orientation),
+		StaggerAxis:   StaggerAxis(xm.StaggerAxis),
+		Width:         xm.Width,
+		Height:        xm.Height,
+		TileWidth:     xm.TileWidth,
+		TileHeight:    xm.TileHeight,
+		HexSideLength: xm.HexSideLength,
+		Infinite:      xm.Infinite != 0,
+		Properties:    xm.Properties.toProperties(),
+	}
+	for _, xt := range xm.Tileset {
+		m.Tilesets = append(m.Tilesets, xt.toTileset())
+	}
+	for _, xl := range xm.Layer {
+		l, err := xl.toLayer()
+		if err != nil {
+			return nil, err
+		}
+		m.Layers = append(m.Layers, l)
+	}
+	for _, og := range xm.ObjectGroup {
+		l := &Layer{
+			Name:       og.Name,
+			Type:       LayerTypeObject,
+			Visible:    boolAttr(og.Visible, true),
+			Opacity:    opacityAttr(og.Opacity),
+			Properties: og.Properties.toProperties(),
+		}
+		for _, o := range og.Object {
+			l.Objects = append(l.Objects, o.toObject())
+		}
+		m.Layers = append(m.Layers, l)
+	}
+	for _, il := range xm.ImageLayer {
+		m.Layers = append(m.Layers, &Layer{
+			Name:       il.Name,
+			Type:       LayerTypeImage,
+			Visible:    boolAttr(il.Visible, true),
+			Opacity:    opacityAttr(il.Opacity),
+			Image:      il.Image.Source,
+			Properties: il.Properties.toProperties(),
+		})
+	}
+	for _, g := range xm.Group {
+		l, err := g.toLayer()
+		if err != nil {
+			return nil, err
+		}
+		m.Layers = append(m.Layers, l)
+	}
+	return m, nil
+}
+
+// DecodeTSX parses a standalone Tiled TSX tileset (XML), as referenced by
+// an external tileset's Source.
+func DecodeTSX(r io.Reader) (*Tileset, error) {
+	var xt xmlTileset
+	if err := xml.NewDecoder(r).Decode(&xt); err != nil {
+		return nil, fmt.Errorf("tiled: decoding TSX: %w", err)
+	}
+	return xt.toTileset(), nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const tmxHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" orientation="orthogonal" width="4" height="2" tilewidth="16" tileheight="16">`
+
+var wantGIDs = []uint32{1, 2, 3, 4, 5, 6, 7, 8}
+
+func decodeTMXLayer(t *testing.T, dataElem string) []uint32 {
+	t.Helper()
+	tmx := tmxHeader + `
+<layer id="1" name="Tile Layer 1" width="4" height="2">
+` + dataElem + `
+</layer>
+</map>`
+	m, err := DecodeTMX(strings.NewReader(tmx))
+	if err != nil {
+		t.Fatalf("DecodeTMX: %v", err)
+	}
+	if len(m.Layers) != 1 {
+		t.Fatalf("got %d layers, want 1", len(m.Layers))
+	}
+	return m.Layers[0].Data
+}
+
+func TestDecodeTMXCSV(t *testing.T) {
+	got := decodeTMXLayer(t, `<data encoding="csv">
+1,2,3,4,
+5,6,7,8
+</data>`)
+	if !reflect.DeepEqual(got, wantGIDs) {
+		t.Errorf("got %v, want %v", got, wantGIDs)
+	}
+}
+
+func TestDecodeTMXBase64Uncompressed(t *testing.T) {
+	got := decodeTMXLayer(t, `<data encoding="base64">
+AQAAAAIAAAADAAAABAAAAAUAAAAGAAAABwAAAAgAAAA=
+</data>`)
+	if !reflect.DeepEqual(got, wantGIDs) {
+		t.Errorf("got %v, want %v", got, wantGIDs)
+	}
+}
+
+func TestDecodeTMXBase64Zlib(t *testing.T) {
+	got := decodeTMXLayer(t, `<data encoding="base64" compression="zlib">
+eJxjZGBgYAJiZiBmAWJWIGYDYnYg5gBiAAIAACU=
+</data>`)
+	if !reflect.DeepEqual(got, wantGIDs) {
+		t.Errorf("got %v, want %v", got, wantGIDs)
+	}
+}
+
+func TestDecodeTMXBase64Gzip(t *testing.T) {
+	got := decodeTMXLayer(t, `<data encoding="base64" compression="gzip">
+H4sIAAAAAAAC/2NkYGBgAmJmIGYBYlYgZgNidiDmAGIATLFdpyAAAAA=
+</data>`)
+	if !reflect.DeepEqual(got, wantGIDs) {
+		t.Errorf("got %v, want %v", got, wantGIDs)
+	}
+}
+
+func TestDecodeTMXPerTileElements(t *testing.T) {
+	got := decodeTMXLayer(t, `<data>
+<tile gid="1"/><tile gid="2"/><tile gid="3"/><tile gid="4"/>
+<tile gid="5"/><tile gid="6"/><tile gid="7"/><tile gid="8"/>
+</data>`)
+	if !reflect.DeepEqual(got, wantGIDs) {
+		t.Errorf("got %v, want %v", got, wantGIDs)
+	}
+}
+
+func TestDecodeTMXUnsupportedCompression(t *testing.T) {
+	tmx := tmxHeader + `
+<layer id="1" name="Tile Layer 1" width="4" height="2">
+<data encoding="base64" compression="zstd">
+AQAAAA==
+</data>
+</layer>
+</map>`
+	if _, err := DecodeTMX(strings.NewReader(tmx)); err == nil {
+		t.Error("expected an error for zstd compression, got nil")
+	}
+}
+
+func TestDecodeTMXInfiniteChunks(t *testing.T) {
+	tmx := `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" orientation="orthogonal" width="0" height="0" tilewidth="16" tileheight="16" infinite="1">
+<layer id="1" name="Tile Layer 1">
+<data encoding="csv">
+<chunk x="0" y="0" width="2" height="2">
+1,2,3,4
+</chunk>
+<chunk x="2" y="0" width="2" height="2">
+5,6,7,8
+</chunk>
+</data>
+</layer>
+</map>`
+	m, err := DecodeTMX(strings.NewReader(tmx))
+	if err != nil {
+		t.Fatalf("DecodeTMX: %v", err)
+	}
+	if len(m.Layers) != 1 || len(m.Layers[0].Chunks) != 2 {
+		t.Fatalf("got layers=%d chunks=%d, want 1 layer with 2 chunks", len(m.Layers), len(m.Layers[0].Chunks))
+	}
+	if got, want := m.Layers[0].Chunks[0].Data, []uint32{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("chunk 0 data: got %v, want %v", got, want)
+	}
+	if got, want := m.Layers[0].Chunks[1].Data, []uint32{5, 6, 7, 8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("chunk 1 data: got %v, want %v", got, want)
+	}
+	if m.Layers[0].Chunks[1].X != 2 {
+		t.Errorf("chunk 1 X: got %d, want 2", m.Layers[0].Chunks[1].X)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+)
+
+// FSRUpscaler is a reusable spatial upscaler that runs AMD FidelityFX Super
+// Resolution 1.0's EASU stage, followed by its RCAS sharpening stage, as
+// Kage shaders. Draw always runs both passes.
+//
+// FSRUpscaler lets a game render its scene at a low internal resolution and
+// then blit it sharply to a higher-resolution destination, such as the
+// window, without the blur of a bilinear or nearest-neighbor scale.
+//
+// A FSRUpscaler is tied to a fixed inputSize/outputSize pair. Create a new
+// one if either size changes, for example on a window resize.
+type FSRUpscaler struct {
+	inputSize  image.Point
+	outputSize image.Point
+
+	easu *Shader
+	rcas *Shader
+
+	// sharpness is the RCAS sharpness parameter. 0 is maximum sharpening.
+	sharpness float32
+
+	mid *Image
+}
+
+// NewFSRUpscaler creates a new FSRUpscaler that upscales images of inputSize
+// to images of outputSize.
+func NewFSRUpscaler(inputSize, outputSize image.Point) (*FSRUpscaler, error) {
+	easu, err := NewShader([]byte(builtinshader.EASUSource))
+	if err != nil {
+		return nil, fmt.Errorf("ebiten: compiling the EASU shader failed: %w", err)
+	}
+	rcas, err := NewShader([]byte(builtinshader.RCASSource))
+	if err != nil {
+		return nil, fmt.Errorf("ebiten: compiling the RCAS shader failed: %w", err)
+	}
+
+	return &FSRUpscaler{
+		inputSize:  inputSize,
+		outputSize: outputSize,
+		easu:       easu,
+		rcas:       rcas,
+		sharpness:  0.2,
+	}, nil
+}
+
+// SetSharpness sets the sharpness passed to the RCAS pass. 0 is maximum
+// sharpening, and larger values sharpen less. The default is 0.2.
+func (u *FSRUpscaler) SetSharpness(sharpness float32) {
+	u.sharpness = sharpness
+}
+
+// Draw upscales src, which must be of the inputSize given to
+// NewFSRUpscaler, into dst, which must be of the outputSize.
+func (u *FSRUpscaler) Draw(dst, src *Image) {
+	if src.Bounds().Dx() != u.inputSize.X || src.Bounds().Dy() != u.inputSize.Y {
+		panic(fmt.Sprintf("ebiten: src must be of size %v, got %v", u.inputSize, src.Bounds().Size()))
+	}
+	if dst.Bounds().Dx() != u.outputSize.X || dst.Bounds().Dy() != u.outputSize.Y {
+		panic(fmt.Sprintf("ebiten: dst must be of size %v, got %v", u.outputSize, dst.Bounds().Size()))
+	}
+
+	con0 := builtinshader.EASUConstants(u.inputSize, u.outputSize)
+
+	if u.mid == nil || u.mid.Bounds().Size() != u.outputSize {
+		u.mid = NewImage(u.outputSize.X, u.outputSize.Y)
+	}
+
+	op := &DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = map[string]interface{}{
+		"Con0": con0,
+	}
+	u.mid.DrawRectShader(u.outputSize.X, u.outputSize.Y, u.easu, op)
+
+	rop := &DrawRectShaderOptions{}
+	rop.Images[0] = u.mid
+	rop.Uniforms = map[string]interface{}{
+		"Sharpness": u.sharpness,
+	}
+	dst.DrawRectShader(u.outputSize.X, u.outputSize.Y, u.rcas, rop)
+}